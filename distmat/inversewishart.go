@@ -0,0 +1,221 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distmat
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+
+	"github.com/gonum/mathext"
+	"github.com/gonum/matrix/mat64"
+)
+
+// InverseWishart is a distribution over d×d positive symmetric definite
+// matrices. It is the conjugate prior for the covariance matrix of a
+// multivariate normal and is parametrized by a scalar degrees of freedom
+// parameter ν and a d×d positive definite scale matrix Ψ.
+//
+// The InverseWishart PDF is given by
+//  p(X) = [|Ψ|^(ν/2) * |X|^(-(ν+d+1)/2) * exp(-tr(Ψ * X^-1)/2)] / [2^(ν*d/2) * Γ(d, ν/2)]
+// where X is a d×d PSD matrix, ν > d-1, tr is the trace and Γ is the multivariate gamma function.
+//
+// See https://en.wikipedia.org/wiki/Inverse-Wishart_distribution for more information.
+type InverseWishart struct {
+	nu  float64
+	src *rand.Rand
+
+	dim       int
+	cholPsi   mat64.Cholesky
+	logdetPsi float64
+	wishart   *Wishart // Wishart(Ψ^-1, ν), used to generate samples by inversion.
+
+	once sync.Once
+	psi  *mat64.SymDense // only stored if needed
+}
+
+// NewInverseWishart returns a new InverseWishart distribution with the given
+// scale matrix and degrees of freedom parameter. NewInverseWishart returns
+// whether the creation was successful.
+//
+// NewInverseWishart panics if nu <= dim-1.
+func NewInverseWishart(psi mat64.Symmetric, nu float64, src *rand.Rand) (*InverseWishart, bool) {
+	var chol mat64.Cholesky
+	ok := chol.Factorize(psi)
+	if !ok {
+		return nil, false
+	}
+	return newInverseWishartFromChol(&chol, nu, src)
+}
+
+// newInverseWishartFromChol is NewInverseWishart, but takes an
+// already-factorized Cholesky decomposition of the scale matrix. It is used
+// by distributions built on top of InverseWishart (e.g. MatrixTDist,
+// MatrixFDist) that cache the factorization of their own scale parameter and
+// would otherwise redundantly re-factorize it on every call to Rand.
+func newInverseWishartFromChol(cholPsi *mat64.Cholesky, nu float64, src *rand.Rand) (*InverseWishart, bool) {
+	dim := cholPsi.Size()
+	if nu <= float64(dim-1) {
+		panic("distmat: nu must be greater than dim-1")
+	}
+
+	psiInv := symInverse(cholPsi)
+	w, ok := NewWishart(psiInv, nu, src)
+	if !ok {
+		return nil, false
+	}
+
+	iw := &InverseWishart{
+		nu:  nu,
+		src: src,
+
+		dim:       dim,
+		cholPsi:   *cholPsi,
+		logdetPsi: cholPsi.LogDet(),
+		wishart:   w,
+	}
+	return iw, true
+}
+
+// MeanSym returns the mean matrix of the distribution as a symmetric matrix.
+// If x is nil, a new matrix is allocated and returned. If x is not nil, the
+// result is stored in-place into x. It must have size d×d or MeanSym will panic.
+//
+// MeanSym panics if nu <= dim+1, since the mean is undefined in that case.
+func (iw *InverseWishart) MeanSym(x *mat64.SymDense) *mat64.SymDense {
+	if iw.nu <= float64(iw.dim+1) {
+		panic("distmat: mean undefined, nu must be greater than dim+1")
+	}
+	if x == nil {
+		x = mat64.NewSymDense(iw.dim, nil)
+	}
+	d := x.Symmetric()
+	if d != iw.dim {
+		panic(badDim)
+	}
+	iw.setPsi()
+	x.CopySym(iw.psi)
+	x.ScaleSym(1/(iw.nu-float64(iw.dim)-1), x)
+	return x
+}
+
+// ProbSym returns the probability of the symmetric matrix x. If x is not positive
+// definite (the Cholesky decomposition fails), it has 0 probability.
+func (iw *InverseWishart) ProbSym(x mat64.Symmetric) float64 {
+	return math.Exp(iw.LogProbSym(x))
+}
+
+// LogProbSym returns the log of the probability of the input symmetric matrix.
+//
+// LogProbSym returns -∞ if the input matrix is not positive definite (the Cholesky
+// decomposition fails).
+func (iw *InverseWishart) LogProbSym(x mat64.Symmetric) float64 {
+	dim := x.Symmetric()
+	if dim != iw.dim {
+		panic(badDim)
+	}
+	var chol mat64.Cholesky
+	ok := chol.Factorize(x)
+	if !ok {
+		return math.Inf(-1)
+	}
+	return iw.logProbSymChol(&chol)
+}
+
+// LogProbSymChol returns the log of the probability of the input symmetric matrix
+// given its Cholesky decomposition.
+func (iw *InverseWishart) LogProbSymChol(cholX *mat64.Cholesky) float64 {
+	dim := cholX.Size()
+	if dim != iw.dim {
+		panic(badDim)
+	}
+	return iw.logProbSymChol(cholX)
+}
+
+func (iw *InverseWishart) logProbSymChol(cholX *mat64.Cholesky) float64 {
+	// The PDF is
+	//  p(X) = [|Ψ|^(ν/2) * |X|^(-(ν+d+1)/2) * exp(-tr(Ψ * X^-1)/2)] / [2^(ν*d/2) * Γ(d, ν/2)]
+	// The LogPDF is thus
+	// ν/2 * logdet(Ψ) - (ν+d+1)/2 * logdet(X) - tr(Ψ * X^-1)/2 - (ν*d/2)*log(2) - loggamma(d, ν/2)
+	logdetx := cholX.LogDet()
+
+	iw.setPsi()
+	// Compute tr(Ψ * X^-1) as tr(X^-1 * Ψ), using X's Cholesky factorization
+	// to solve rather than explicitly inverting X.
+	var xinvpsi mat64.Dense
+	err := xinvpsi.SolveCholesky(cholX, iw.psi)
+	if err != nil {
+		return math.Inf(-1)
+	}
+	tr := mat64.Trace(&xinvpsi)
+
+	fnu := iw.nu
+	fdim := float64(iw.dim)
+
+	return 0.5*(fnu*iw.logdetPsi-(fnu+fdim+1)*logdetx-tr-fnu*fdim*math.Ln2) - mathext.MvLgamma(0.5*fnu, iw.dim)
+}
+
+// RandSym generates a random symmetric matrix from the distribution.
+func (iw *InverseWishart) RandSym(x *mat64.SymDense) *mat64.SymDense {
+	var c mat64.Cholesky
+	iw.RandChol(&c)
+	if x == nil {
+		x = mat64.NewSymDense(iw.dim, nil)
+	}
+	x.FromCholesky(&c)
+	return x
+}
+
+// RandChol generates the Cholesky decomposition of a random matrix from the distribution.
+func (iw *InverseWishart) RandChol(c *mat64.Cholesky) *mat64.Cholesky {
+	// Draw Y ~ Wishart(Ψ^-1, ν) and invert, since X = Y^-1 ~ InverseWishart(Ψ, ν).
+	// The inverse of Y is refactorized rather than carried through in
+	// triangular form, since the inverse of a Cholesky factor is not itself
+	// the Cholesky factor of the inverse matrix without an additional
+	// reversal step.
+	var cy mat64.Cholesky
+	iw.wishart.RandChol(&cy)
+	x := symInverse(&cy)
+
+	if c == nil {
+		c = &mat64.Cholesky{}
+	}
+	ok := c.Factorize(x)
+	if !ok {
+		panic("distmat: sampled matrix is not positive definite")
+	}
+	return c
+}
+
+// setPsi computes and stores the scale matrix of the distribution.
+func (iw *InverseWishart) setPsi() {
+	iw.once.Do(func() {
+		iw.psi = mat64.NewSymDense(iw.dim, nil)
+		iw.psi.FromCholesky(&iw.cholPsi)
+	})
+}
+
+// symInverse returns the inverse of the symmetric positive definite matrix
+// whose Cholesky factorization is chol.
+func symInverse(chol *mat64.Cholesky) *mat64.SymDense {
+	dim := chol.Size()
+	id := mat64.NewDense(dim, dim, nil)
+	for i := 0; i < dim; i++ {
+		id.Set(i, i, 1)
+	}
+	var inv mat64.Dense
+	err := inv.SolveCholesky(chol, id)
+	if err != nil {
+		panic("distmat: matrix is singular")
+	}
+
+	sym := mat64.NewSymDense(dim, nil)
+	for i := 0; i < dim; i++ {
+		for j := i; j < dim; j++ {
+			sym.SetSym(i, j, inv.At(i, j))
+		}
+	}
+	return sym
+}
@@ -0,0 +1,86 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distmat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+func TestInverseWishartMeanSym(t *testing.T) {
+	psi := 4.0
+	nu := 5.0
+	src := rand.New(rand.NewSource(1))
+	iw, ok := NewInverseWishart(mat64.NewSymDense(1, []float64{psi}), nu, src)
+	if !ok {
+		t.Fatal("NewInverseWishart failed")
+	}
+	got := iw.MeanSym(nil).At(0, 0)
+	want := psi / (nu - 1 - 1)
+	if math.Abs(got-want) > 1e-12 {
+		t.Errorf("mean mismatch: got %v, want %v", got, want)
+	}
+}
+
+// For dim=1, InverseWishart(psi, nu) reduces to an Inverse-Gamma distribution
+// with shape alpha = nu/2 and scale beta = psi/2.
+func TestInverseWishartLogProbSym(t *testing.T) {
+	psi := 4.0
+	nu := 5.0
+	x := 2.5
+
+	alpha := nu / 2
+	beta := psi / 2
+	lgAlpha, _ := math.Lgamma(alpha)
+	want := alpha*math.Log(beta) - lgAlpha - (alpha+1)*math.Log(x) - beta/x
+
+	src := rand.New(rand.NewSource(1))
+	iw, ok := NewInverseWishart(mat64.NewSymDense(1, []float64{psi}), nu, src)
+	if !ok {
+		t.Fatal("NewInverseWishart failed")
+	}
+	got := iw.LogProbSym(mat64.NewSymDense(1, []float64{x}))
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("logprob mismatch: got %v, want %v", got, want)
+	}
+}
+
+func TestInverseWishartRandSym(t *testing.T) {
+	const dim = 3
+	psi := mat64.NewSymDense(dim, nil)
+	for i := 0; i < dim; i++ {
+		psi.SetSym(i, i, 2)
+	}
+	src := rand.New(rand.NewSource(1))
+	iw, ok := NewInverseWishart(psi, float64(dim+3), src)
+	if !ok {
+		t.Fatal("NewInverseWishart failed")
+	}
+
+	for i := 0; i < 10; i++ {
+		x := iw.RandSym(nil)
+		r, c := x.Dims()
+		if r != dim || c != dim {
+			t.Fatalf("wrong dims: got (%d,%d), want (%d,%d)", r, c, dim, dim)
+		}
+		var chol mat64.Cholesky
+		if !chol.Factorize(x) {
+			t.Fatal("sampled matrix is not positive definite")
+		}
+	}
+}
+
+func TestNewInverseWishartPanicsOnSmallNu(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for nu <= dim-1")
+		}
+	}()
+	psi := mat64.NewSymDense(2, []float64{1, 0, 0, 1})
+	NewInverseWishart(psi, 1, rand.New(rand.NewSource(1)))
+}
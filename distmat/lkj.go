@@ -0,0 +1,138 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distmat
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/gonum/matrix/mat64"
+	"github.com/gonum/stat/distuv"
+)
+
+// LKJ is the Lewandowski-Kurowicka-Joe distribution over d×d correlation
+// matrices (symmetric, unit diagonal, positive definite). It is parametrized
+// by a dimension d and a shape parameter η > 0; η = 1 gives a density that is
+// uniform over the space of valid correlation matrices, η > 1 concentrates
+// mass near the identity and η < 1 spreads mass towards strong correlations.
+//
+// The LKJ PDF is given by
+//  p(R) ∝ |R|^(η-1)
+//
+// See Lewandowski, Kurowicka and Joe (2009), "Generating random correlation
+// matrices based on vines and extended onion method".
+type LKJ struct {
+	dim int
+	eta float64
+	src *rand.Rand
+}
+
+// NewLKJ returns a new LKJ distribution with the given dimension and shape
+// parameter.
+//
+// NewLKJ panics if eta <= 0.
+func NewLKJ(dim int, eta float64, src *rand.Rand) *LKJ {
+	if eta <= 0 {
+		panic("distmat: eta must be positive")
+	}
+	return &LKJ{dim: dim, eta: eta, src: src}
+}
+
+// Prob returns the probability of the correlation matrix r.
+func (l *LKJ) Prob(r mat64.Symmetric) float64 {
+	return math.Exp(l.LogProb(r))
+}
+
+// LogProb returns the log of the probability of the input correlation matrix.
+//
+// LogProb returns -∞ if r is not positive definite.
+func (l *LKJ) LogProb(r mat64.Symmetric) float64 {
+	dim := r.Symmetric()
+	if dim != l.dim {
+		panic(badDim)
+	}
+	var chol mat64.Cholesky
+	ok := chol.Factorize(r)
+	if !ok {
+		return math.Inf(-1)
+	}
+	logdetr := chol.LogDet()
+	return (l.eta-1)*logdetr - l.logNormConst()
+}
+
+// logNormConst returns the log of the normalizing constant of the density,
+// derived from the per-partial-correlation Beta normalizing constants used
+// by the onion/vine construction in Rand.
+func (l *LKJ) logNormConst() float64 {
+	var c float64
+	for k := 1; k < l.dim; k++ {
+		m := float64(l.dim - k)
+		beta := l.eta + (m-1)/2
+		lg, _ := math.Lgamma(beta)
+		lg2, _ := math.Lgamma(2 * beta)
+		logBeta := 2*lg - lg2
+		c += (2*l.eta-2+m)*m*math.Ln2 + m*logBeta
+	}
+	return c
+}
+
+// Rand generates a random correlation matrix from the distribution using the
+// onion/vine method: the matrix is grown one row and column at a time, with
+// each new row's squared norm drawn from a Beta distribution and its
+// direction drawn uniformly from the unit sphere.
+func (l *LKJ) Rand(dst *mat64.SymDense) *mat64.SymDense {
+	d := l.dim
+	if dst == nil {
+		dst = mat64.NewSymDense(d, nil)
+	}
+	for i := 0; i < d; i++ {
+		dst.SetSym(i, i, 1)
+	}
+	if d == 1 {
+		return dst
+	}
+
+	// L is the lower Cholesky factor of the correlation matrix being built.
+	L := mat64.NewDense(d, d, nil)
+	L.Set(0, 0, 1)
+
+	normal := distuv.Normal{Mu: 0, Sigma: 1, Source: l.src}
+	for k := 1; k < d; k++ {
+		// Row k (0-indexed) grows the matrix to its (k+1)-th variable. Its
+		// squared norm y is Beta(k/2, η+(d-k-1)/2) distributed (Lewandowski,
+		// Kurowicka and Joe 2009), and its direction is uniform on the unit
+		// sphere in R^k, which a normalized standard normal vector gives.
+		a := float64(k) / 2
+		b := l.eta + float64(d-k-1)/2
+		y := distuv.Beta{
+			Alpha: a,
+			Beta:  b,
+			Src:   l.src,
+		}.Rand()
+
+		w := make([]float64, k)
+		var norm2 float64
+		for i := range w {
+			w[i] = normal.Rand()
+			norm2 += w[i] * w[i]
+		}
+		scale := math.Sqrt(y / norm2)
+		for i := range w {
+			w[i] *= scale
+			L.Set(k, i, w[i])
+		}
+
+		L.Set(k, k, math.Sqrt(1-y))
+	}
+
+	var rMat mat64.Dense
+	rMat.Mul(L, L.T())
+	for i := 0; i < d; i++ {
+		for j := i; j < d; j++ {
+			dst.SetSym(i, j, rMat.At(i, j))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,153 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distmat
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/gonum/mathext"
+	"github.com/gonum/matrix/mat64"
+)
+
+// MatrixBeta is a matrix-variate Beta distribution over d×d positive
+// semi-definite matrices X with eigenvalues in (0, 1), i.e. matrices in the
+// interval (0, I). It is parametrized by a dimension d and two degrees of
+// freedom parameters n1 and n2.
+//
+// The MatrixBeta PDF is given by
+//  p(X) = |X|^((n1-d-1)/2) * |I-X|^((n2-d-1)/2) / B_d(n1/2, n2/2)
+// where B_d is the multivariate Beta function.
+type MatrixBeta struct {
+	dim    int
+	n1, n2 float64
+	src    *rand.Rand
+}
+
+// NewMatrixBeta returns a new MatrixBeta distribution with the given
+// dimension and degrees of freedom.
+//
+// NewMatrixBeta panics if n1 <= dim-1 or n2 <= dim-1.
+func NewMatrixBeta(dim int, n1, n2 float64, src *rand.Rand) *MatrixBeta {
+	if n1 <= float64(dim-1) || n2 <= float64(dim-1) {
+		panic("distmat: n1 and n2 must be greater than dim-1")
+	}
+	return &MatrixBeta{dim: dim, n1: n1, n2: n2, src: src}
+}
+
+// Prob returns the probability of the symmetric matrix x.
+func (mb *MatrixBeta) Prob(x mat64.Symmetric) float64 {
+	return math.Exp(mb.LogProb(x))
+}
+
+// LogProb returns the log of the probability of the input symmetric matrix.
+//
+// LogProb returns -∞ if x or I-x is not positive definite.
+func (mb *MatrixBeta) LogProb(x mat64.Symmetric) float64 {
+	dim := x.Symmetric()
+	if dim != mb.dim {
+		panic(badDim)
+	}
+
+	var cholX mat64.Cholesky
+	ok := cholX.Factorize(x)
+	if !ok {
+		return math.Inf(-1)
+	}
+	logdetx := cholX.LogDet()
+
+	eye := mat64.NewSymDense(dim, nil)
+	for i := 0; i < dim; i++ {
+		eye.SetSym(i, i, 1)
+	}
+	var imxDense mat64.Dense
+	imxDense.Sub(eye, x)
+	imx := mat64.NewSymDense(dim, nil)
+	for i := 0; i < dim; i++ {
+		for j := i; j < dim; j++ {
+			imx.SetSym(i, j, imxDense.At(i, j))
+		}
+	}
+	var cholImX mat64.Cholesky
+	ok = cholImX.Factorize(imx)
+	if !ok {
+		return math.Inf(-1)
+	}
+	logdetimx := cholImX.LogDet()
+
+	fd := float64(dim)
+	logBetaD := mathext.MvLgamma(0.5*mb.n1, dim) + mathext.MvLgamma(0.5*mb.n2, dim) - mathext.MvLgamma(0.5*(mb.n1+mb.n2), dim)
+
+	return 0.5*(mb.n1-fd-1)*logdetx + 0.5*(mb.n2-fd-1)*logdetimx - logBetaD
+}
+
+// RandSym generates a random symmetric matrix from the distribution, by
+// drawing S1 ~ Wishart(I, n1), S2 ~ Wishart(I, n2) and returning
+// X = (S1+S2)^-1/2 * S1 * (S1+S2)^-1/2.
+func (mb *MatrixBeta) RandSym(x *mat64.SymDense) *mat64.SymDense {
+	identity := mat64.NewSymDense(mb.dim, nil)
+	for i := 0; i < mb.dim; i++ {
+		identity.SetSym(i, i, 1)
+	}
+
+	w1, ok := NewWishart(identity, mb.n1, mb.src)
+	if !ok {
+		panic("distmat: invalid parameters")
+	}
+	w2, ok := NewWishart(identity, mb.n2, mb.src)
+	if !ok {
+		panic("distmat: invalid parameters")
+	}
+	var s1, s2 mat64.SymDense
+	w1.RandSym(&s1)
+	w2.RandSym(&s2)
+
+	var sum mat64.SymDense
+	sum.AddSym(&s1, &s2)
+	sumNegHalf := symPowNegHalf(&sum)
+
+	var tmp, res mat64.Dense
+	tmp.Mul(sumNegHalf, &s1)
+	res.Mul(&tmp, sumNegHalf)
+
+	if x == nil {
+		x = mat64.NewSymDense(mb.dim, nil)
+	}
+	for i := 0; i < mb.dim; i++ {
+		for j := i; j < mb.dim; j++ {
+			x.SetSym(i, j, res.At(i, j))
+		}
+	}
+	return x
+}
+
+// symPowNegHalf returns a^(-1/2) for the symmetric positive definite matrix a,
+// computed via its eigendecomposition.
+func symPowNegHalf(a mat64.Symmetric) *mat64.SymDense {
+	dim := a.Symmetric()
+	var eig mat64.EigenSym
+	ok := eig.Factorize(a, true)
+	if !ok {
+		panic("distmat: eigendecomposition failed")
+	}
+	values := eig.Values(nil)
+	vectors := eig.Vectors()
+
+	d := mat64.NewDense(dim, dim, nil)
+	for i := 0; i < dim; i++ {
+		d.Set(i, i, 1/math.Sqrt(values[i]))
+	}
+	var vd, vdvt mat64.Dense
+	vd.Mul(vectors, d)
+	vdvt.Mul(&vd, vectors.T())
+
+	sym := mat64.NewSymDense(dim, nil)
+	for i := 0; i < dim; i++ {
+		for j := i; j < dim; j++ {
+			sym.SetSym(i, j, vdvt.At(i, j))
+		}
+	}
+	return sym
+}
@@ -0,0 +1,54 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distmat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+// For dim=1, MatrixBeta(1, n1, n2) reduces to the ordinary Beta(n1/2, n2/2)
+// distribution.
+func TestMatrixBetaLogProb(t *testing.T) {
+	n1, n2, x := 4.0, 6.0, 0.3
+	a, b := n1/2, n2/2
+
+	lgA, _ := math.Lgamma(a)
+	lgB, _ := math.Lgamma(b)
+	lgAB, _ := math.Lgamma(a + b)
+	want := (a-1)*math.Log(x) + (b-1)*math.Log(1-x) - (lgA + lgB - lgAB)
+
+	mb := NewMatrixBeta(1, n1, n2, rand.New(rand.NewSource(1)))
+	got := mb.LogProb(mat64.NewSymDense(1, []float64{x}))
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("logprob mismatch: got %v, want %v", got, want)
+	}
+}
+
+func TestMatrixBetaRandSymEigenvalues(t *testing.T) {
+	const dim = 3
+	mb := NewMatrixBeta(dim, float64(dim+3), float64(dim+3), rand.New(rand.NewSource(1)))
+
+	for i := 0; i < 10; i++ {
+		x := mb.RandSym(nil)
+		r, c := x.Dims()
+		if r != dim || c != dim {
+			t.Fatalf("wrong dims: got (%d,%d), want (%d,%d)", r, c, dim, dim)
+		}
+
+		var eig mat64.EigenSym
+		if !eig.Factorize(x, false) {
+			t.Fatal("eigendecomposition failed")
+		}
+		for _, v := range eig.Values(nil) {
+			if v <= 0 || v >= 1 {
+				t.Errorf("eigenvalue out of (0,1): got %v", v)
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distmat
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/gonum/mathext"
+	"github.com/gonum/matrix/mat64"
+)
+
+// MatrixFDist is a matrix-variate F distribution over d×d positive
+// semi-definite matrices. It is parametrized by two degrees of freedom
+// parameters n1 and n2, and a d×d positive definite scale matrix Ψ.
+//
+// The MatrixFDist PDF is given by
+//  p(X) = [Γ_d((n1+ν)/2) / (Γ_d(n1/2) * Γ_d(ν/2))] * |X|^((n1-d-1)/2) * |Ψ|^(ν/2) * |Ψ+X|^(-(n1+ν)/2)
+// where ν = n2+d-1, X is a d×d PSD matrix and Γ_d is the multivariate gamma function.
+//
+// A MatrixFDist sample can equivalently be generated by drawing
+// Σ ~ InverseWishart(Ψ, n2+d-1) and then X | Σ ~ Wishart(Σ, n1).
+type MatrixFDist struct {
+	n1, n2 float64
+	src    *rand.Rand
+
+	dim       int
+	psi       *mat64.SymDense
+	cholPsi   mat64.Cholesky
+	logdetPsi float64
+}
+
+// NewMatrixFDist returns a new MatrixFDist distribution with the given
+// degrees of freedom and scale matrix. NewMatrixFDist returns whether the
+// creation was successful.
+//
+// NewMatrixFDist panics if n1 <= dim-1 or n2 <= 0, since X | Σ ~ Wishart(Σ,
+// n1) and Σ ~ InverseWishart(Ψ, n2+dim-1) both require those bounds.
+func NewMatrixFDist(n1, n2 float64, psi mat64.Symmetric, src *rand.Rand) (*MatrixFDist, bool) {
+	dim := psi.Symmetric()
+	if n1 <= float64(dim-1) {
+		panic("distmat: n1 must be greater than dim-1")
+	}
+	if n2 <= 0 {
+		panic("distmat: n2 must be positive")
+	}
+	var chol mat64.Cholesky
+	ok := chol.Factorize(psi)
+	if !ok {
+		return nil, false
+	}
+
+	psid := mat64.NewSymDense(dim, nil)
+	psid.CopySym(psi)
+
+	f := &MatrixFDist{
+		n1:  n1,
+		n2:  n2,
+		src: src,
+
+		dim:       dim,
+		psi:       psid,
+		cholPsi:   chol,
+		logdetPsi: chol.LogDet(),
+	}
+	return f, true
+}
+
+// Prob returns the probability of the symmetric matrix x.
+func (f *MatrixFDist) Prob(x mat64.Symmetric) float64 {
+	return math.Exp(f.LogProb(x))
+}
+
+// LogProb returns the log of the probability of the input symmetric matrix.
+//
+// LogProb returns -∞ if x or Ψ+x is not positive definite.
+func (f *MatrixFDist) LogProb(x mat64.Symmetric) float64 {
+	dim := x.Symmetric()
+	if dim != f.dim {
+		panic(badDim)
+	}
+
+	var cholX mat64.Cholesky
+	ok := cholX.Factorize(x)
+	if !ok {
+		return math.Inf(-1)
+	}
+	logdetx := cholX.LogDet()
+
+	var sum mat64.SymDense
+	sum.AddSym(f.psi, x)
+	var cholSum mat64.Cholesky
+	ok = cholSum.Factorize(&sum)
+	if !ok {
+		return math.Inf(-1)
+	}
+	logdetsum := cholSum.LogDet()
+
+	fd := float64(f.dim)
+	n1 := f.n1
+	// n2 here is ν = n2+d-1, the InverseWishart degrees of freedom used by RandChol.
+	n2 := f.n2 + fd - 1
+
+	return mathext.MvLgamma(0.5*(n1+n2), f.dim) - mathext.MvLgamma(0.5*n1, f.dim) - mathext.MvLgamma(0.5*n2, f.dim) +
+		0.5*(n1-fd-1)*logdetx + 0.5*n2*f.logdetPsi - 0.5*(n1+n2)*logdetsum
+}
+
+// RandSym generates a random symmetric matrix from the distribution.
+func (f *MatrixFDist) RandSym(x *mat64.SymDense) *mat64.SymDense {
+	var c mat64.Cholesky
+	f.RandChol(&c)
+	if x == nil {
+		x = mat64.NewSymDense(f.dim, nil)
+	}
+	x.FromCholesky(&c)
+	return x
+}
+
+// RandChol generates the Cholesky decomposition of a random matrix from the distribution.
+func (f *MatrixFDist) RandChol(c *mat64.Cholesky) *mat64.Cholesky {
+	// f.cholPsi is already factorized, so reuse it instead of re-factorizing
+	// Ψ from scratch on every call.
+	iw, ok := newInverseWishartFromChol(&f.cholPsi, f.n2+float64(f.dim)-1, f.src)
+	if !ok {
+		panic("distmat: invalid parameters")
+	}
+	var sigma mat64.SymDense
+	iw.RandSym(&sigma)
+
+	w, ok := NewWishart(&sigma, f.n1, f.src)
+	if !ok {
+		panic("distmat: invalid parameters")
+	}
+	return w.RandChol(c)
+}
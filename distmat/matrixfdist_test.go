@@ -0,0 +1,79 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distmat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+// For dim=1, Γ_1 is the ordinary gamma function, so LogProb can be checked
+// directly against the scalar matrix-F density.
+func TestMatrixFDistLogProb(t *testing.T) {
+	n1, n2, psi, x := 6.0, 8.0, 2.0, 1.5
+
+	lg12, _ := math.Lgamma(0.5 * (n1 + n2))
+	lg1, _ := math.Lgamma(0.5 * n1)
+	lg2, _ := math.Lgamma(0.5 * n2)
+	want := lg12 - lg1 - lg2 + 0.5*(n1-1-1)*math.Log(x) + 0.5*n2*math.Log(psi) - 0.5*(n1+n2)*math.Log(psi+x)
+
+	src := rand.New(rand.NewSource(1))
+	f, ok := NewMatrixFDist(n1, n2, mat64.NewSymDense(1, []float64{psi}), src)
+	if !ok {
+		t.Fatal("NewMatrixFDist failed")
+	}
+	got := f.LogProb(mat64.NewSymDense(1, []float64{x}))
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("logprob mismatch: got %v, want %v", got, want)
+	}
+}
+
+func TestMatrixFDistRandSym(t *testing.T) {
+	const dim = 3
+	psi := mat64.NewSymDense(dim, nil)
+	for i := 0; i < dim; i++ {
+		psi.SetSym(i, i, 1)
+	}
+	src := rand.New(rand.NewSource(1))
+	f, ok := NewMatrixFDist(float64(dim+2), float64(dim+2), psi, src)
+	if !ok {
+		t.Fatal("NewMatrixFDist failed")
+	}
+
+	for i := 0; i < 10; i++ {
+		x := f.RandSym(nil)
+		r, c := x.Dims()
+		if r != dim || c != dim {
+			t.Fatalf("wrong dims: got (%d,%d), want (%d,%d)", r, c, dim, dim)
+		}
+		var chol mat64.Cholesky
+		if !chol.Factorize(x) {
+			t.Fatal("sampled matrix is not positive definite")
+		}
+	}
+}
+
+func TestNewMatrixFDistPanicsOnSmallN1(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for n1 <= dim-1")
+		}
+	}()
+	psi := mat64.NewSymDense(2, []float64{1, 0, 0, 1})
+	NewMatrixFDist(1, 5, psi, rand.New(rand.NewSource(1)))
+}
+
+func TestNewMatrixFDistPanicsOnNonPositiveN2(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for n2 <= 0")
+		}
+	}()
+	psi := mat64.NewSymDense(2, []float64{1, 0, 0, 1})
+	NewMatrixFDist(5, 0, psi, rand.New(rand.NewSource(1)))
+}
@@ -0,0 +1,175 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distmat
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/gonum/matrix/mat64"
+	"github.com/gonum/stat/distuv"
+)
+
+// MatrixNormal is a distribution over d×p matrices. It is parametrized by a
+// d×p mean matrix M, a d×d among-row covariance matrix U and a p×p
+// among-column covariance matrix V, both of which must be positive definite.
+//
+// The MatrixNormal PDF is given by
+//  p(X) = exp(-tr(V^-1 * (X-M)^T * U^-1 * (X-M))/2) / [(2*pi)^(d*p/2) * |U|^(p/2) * |V|^(d/2)]
+// where X is a d×p matrix and tr is the trace.
+//
+// MatrixNormal is the natural companion to Wishart and InverseWishart, and
+// underlies conjugate MatrixNormal–InverseWishart hierarchical models.
+//
+// See https://en.wikipedia.org/wiki/Matrix_normal_distribution for more information.
+type MatrixNormal struct {
+	src *rand.Rand
+
+	d, p int
+	mean *mat64.Dense
+
+	cholU   mat64.Cholesky
+	cholV   mat64.Cholesky
+	logdetU float64
+	logdetV float64
+}
+
+// NewMatrixNormal returns a new MatrixNormal distribution with the given
+// mean, row covariance and column covariance. NewMatrixNormal returns whether
+// the creation was successful.
+//
+// NewMatrixNormal panics if the dimensions of mean, u and v are not consistent.
+func NewMatrixNormal(mean *mat64.Dense, u, v mat64.Symmetric, src *rand.Rand) (*MatrixNormal, bool) {
+	var cholV mat64.Cholesky
+	ok := cholV.Factorize(v)
+	if !ok {
+		return nil, false
+	}
+	return newMatrixNormalFromChol(mean, u, &cholV, src)
+}
+
+// newMatrixNormalFromChol is NewMatrixNormal, but takes an already-factorized
+// Cholesky decomposition of the column covariance v. It is used by
+// distributions built on top of MatrixNormal (e.g. MatrixTDist) that cache
+// the factorization of their own column-covariance parameter and would
+// otherwise redundantly re-factorize it on every call to Rand.
+func newMatrixNormalFromChol(mean *mat64.Dense, u mat64.Symmetric, cholV *mat64.Cholesky, src *rand.Rand) (*MatrixNormal, bool) {
+	d, p := mean.Dims()
+	if d != u.Symmetric() {
+		panic(badDim)
+	}
+	if p != cholV.Size() {
+		panic(badDim)
+	}
+
+	var cholU mat64.Cholesky
+	ok := cholU.Factorize(u)
+	if !ok {
+		return nil, false
+	}
+
+	mn := &MatrixNormal{
+		src: src,
+
+		d:    d,
+		p:    p,
+		mean: mat64.DenseCopyOf(mean),
+
+		cholU:   cholU,
+		cholV:   *cholV,
+		logdetU: cholU.LogDet(),
+		logdetV: cholV.LogDet(),
+	}
+	return mn, true
+}
+
+// Mean returns the mean matrix of the distribution. If x is nil, a new
+// matrix is allocated and returned. If x is not nil, the result is stored
+// in-place into x. It must have size d×p or Mean will panic.
+func (mn *MatrixNormal) Mean(x *mat64.Dense) *mat64.Dense {
+	if x == nil {
+		x = mat64.NewDense(mn.d, mn.p, nil)
+	}
+	r, c := x.Dims()
+	if r != mn.d || c != mn.p {
+		panic(badDim)
+	}
+	x.Copy(mn.mean)
+	return x
+}
+
+// Prob returns the probability of the matrix x.
+func (mn *MatrixNormal) Prob(x *mat64.Dense) float64 {
+	return math.Exp(mn.LogProb(x))
+}
+
+// LogProb returns the log of the probability of the input matrix x.
+func (mn *MatrixNormal) LogProb(x *mat64.Dense) float64 {
+	r, c := x.Dims()
+	if r != mn.d || c != mn.p {
+		panic(badDim)
+	}
+
+	var diff mat64.Dense
+	diff.Sub(x, mn.mean)
+
+	// tr(V^-1 * (X-M)^T * U^-1 * (X-M))
+	var uinvDiff mat64.Dense
+	err := uinvDiff.SolveCholesky(&mn.cholU, &diff)
+	if err != nil {
+		return math.Inf(-1)
+	}
+	var inner mat64.Dense
+	inner.Mul(diff.T(), &uinvDiff)
+	var vinvInner mat64.Dense
+	err = vinvInner.SolveCholesky(&mn.cholV, &inner)
+	if err != nil {
+		return math.Inf(-1)
+	}
+	tr := mat64.Trace(&vinvInner)
+
+	fd := float64(mn.d)
+	fp := float64(mn.p)
+
+	return -0.5*(tr+fd*fp*math.Log(2*math.Pi)) - 0.5*fp*mn.logdetU - 0.5*fd*mn.logdetV
+}
+
+// Rand generates a random matrix from the distribution. If x is nil, a new
+// matrix is allocated and returned. If x is not nil, the result is stored
+// in-place into x. It must have size d×p or Rand will panic.
+func (mn *MatrixNormal) Rand(x *mat64.Dense) *mat64.Dense {
+	if x == nil {
+		x = mat64.NewDense(mn.d, mn.p, nil)
+	}
+	r, c := x.Dims()
+	if r != mn.d || c != mn.p {
+		panic(badDim)
+	}
+
+	norm := distuv.Normal{
+		Mu:     0,
+		Sigma:  1,
+		Source: mn.src,
+	}
+	z := mat64.NewDense(mn.d, mn.p, nil)
+	for i := 0; i < mn.d; i++ {
+		for j := 0; j < mn.p; j++ {
+			z.Set(i, j, norm.Rand())
+		}
+	}
+
+	// X = M + A*Z*B^T, where A and B are the lower Cholesky factors of U and V.
+	var a, b mat64.TriDense
+	a.LFromCholesky(&mn.cholU)
+	b.LFromCholesky(&mn.cholV)
+
+	var az mat64.Dense
+	az.Mul(&a, z)
+	var azbt mat64.Dense
+	azbt.Mul(&az, b.T())
+
+	x.Add(mn.mean, &azbt)
+	return x
+}
@@ -0,0 +1,72 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distmat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+func TestMatrixNormalMean(t *testing.T) {
+	mean := mat64.NewDense(2, 2, []float64{1, 2, 3, 4})
+	u := mat64.NewSymDense(2, []float64{1, 0, 0, 1})
+	v := mat64.NewSymDense(2, []float64{1, 0, 0, 1})
+	src := rand.New(rand.NewSource(1))
+	mn, ok := NewMatrixNormal(mean, u, v, src)
+	if !ok {
+		t.Fatal("NewMatrixNormal failed")
+	}
+	got := mn.Mean(nil)
+	if !mat64.Equal(got, mean) {
+		t.Errorf("mean mismatch: got %v, want %v", got, mean)
+	}
+}
+
+// For d=p=1, MatrixNormal(m, u, v) reduces to a univariate normal with mean m
+// and variance u*v.
+func TestMatrixNormalLogProb(t *testing.T) {
+	m, u, v, x := 1.5, 2.0, 3.0, 4.0
+
+	variance := u * v
+	want := -0.5*math.Log(2*math.Pi*variance) - (x-m)*(x-m)/(2*variance)
+
+	mean := mat64.NewDense(1, 1, []float64{m})
+	src := rand.New(rand.NewSource(1))
+	mn, ok := NewMatrixNormal(mean, mat64.NewSymDense(1, []float64{u}), mat64.NewSymDense(1, []float64{v}), src)
+	if !ok {
+		t.Fatal("NewMatrixNormal failed")
+	}
+	got := mn.LogProb(mat64.NewDense(1, 1, []float64{x}))
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("logprob mismatch: got %v, want %v", got, want)
+	}
+}
+
+func TestMatrixNormalRand(t *testing.T) {
+	const d, p = 2, 3
+	mean := mat64.NewDense(d, p, nil)
+	u := mat64.NewSymDense(d, nil)
+	for i := 0; i < d; i++ {
+		u.SetSym(i, i, 1)
+	}
+	v := mat64.NewSymDense(p, nil)
+	for i := 0; i < p; i++ {
+		v.SetSym(i, i, 1)
+	}
+	src := rand.New(rand.NewSource(1))
+	mn, ok := NewMatrixNormal(mean, u, v, src)
+	if !ok {
+		t.Fatal("NewMatrixNormal failed")
+	}
+
+	x := mn.Rand(nil)
+	r, c := x.Dims()
+	if r != d || c != p {
+		t.Fatalf("wrong dims: got (%d,%d), want (%d,%d)", r, c, d, p)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distmat
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/gonum/mathext"
+	"github.com/gonum/matrix/mat64"
+)
+
+// MatrixTDist is a matrix-variate Student's t distribution over d×p matrices.
+// It is parametrized by a degrees of freedom parameter ν, a d×p mean matrix
+// M, a d×d among-row scale matrix U and a p×p among-column scale matrix V,
+// both of which must be positive definite.
+//
+// The MatrixTDist PDF is given by
+//  p(X) = [Γ_d((ν+d+p-1)/2) / Γ_d((ν+d-1)/2)] * |U|^(-p/2) * |V|^(-d/2) * (π)^(-d*p/2) *
+//         |I_d + U^-1 * (X-M) * V^-1 * (X-M)^T|^(-(ν+d+p-1)/2)
+// where X is a d×p matrix and Γ_d is the multivariate gamma function.
+//
+// A MatrixTDist sample can equivalently be generated by drawing
+// Σ ~ InverseWishart(U, ν+d-1) and then X | Σ ~ MatrixNormal(M, Σ, V).
+type MatrixTDist struct {
+	nu  float64
+	src *rand.Rand
+
+	d, p int
+	mean *mat64.Dense
+
+	cholU   mat64.Cholesky
+	cholV   mat64.Cholesky
+	logdetU float64
+	logdetV float64
+}
+
+// NewMatrixTDist returns a new MatrixTDist distribution with the given
+// degrees of freedom, mean, row scale and column scale. NewMatrixTDist
+// returns whether the creation was successful.
+//
+// NewMatrixTDist panics if the dimensions of mean, u and v are not consistent.
+func NewMatrixTDist(nu float64, mean *mat64.Dense, u, v mat64.Symmetric, src *rand.Rand) (*MatrixTDist, bool) {
+	d, p := mean.Dims()
+	if d != u.Symmetric() {
+		panic(badDim)
+	}
+	if p != v.Symmetric() {
+		panic(badDim)
+	}
+
+	var cholU, cholV mat64.Cholesky
+	ok := cholU.Factorize(u)
+	if !ok {
+		return nil, false
+	}
+	ok = cholV.Factorize(v)
+	if !ok {
+		return nil, false
+	}
+
+	t := &MatrixTDist{
+		nu:  nu,
+		src: src,
+
+		d:    d,
+		p:    p,
+		mean: mat64.DenseCopyOf(mean),
+
+		cholU:   cholU,
+		cholV:   cholV,
+		logdetU: cholU.LogDet(),
+		logdetV: cholV.LogDet(),
+	}
+	return t, true
+}
+
+// Prob returns the probability of the matrix x.
+func (t *MatrixTDist) Prob(x *mat64.Dense) float64 {
+	return math.Exp(t.LogProb(x))
+}
+
+// LogProb returns the log of the probability of the input matrix x.
+func (t *MatrixTDist) LogProb(x *mat64.Dense) float64 {
+	r, c := x.Dims()
+	if r != t.d || c != t.p {
+		panic(badDim)
+	}
+
+	var diff mat64.Dense
+	diff.Sub(x, t.mean)
+
+	var uinvDiff mat64.Dense
+	err := uinvDiff.SolveCholesky(&t.cholU, &diff) // U^-1 * (X-M)
+	if err != nil {
+		return math.Inf(-1)
+	}
+	var vinvDiffT mat64.Dense
+	err = vinvDiffT.SolveCholesky(&t.cholV, diff.T()) // V^-1 * (X-M)^T
+	if err != nil {
+		return math.Inf(-1)
+	}
+	var inner mat64.Dense
+	inner.Mul(&uinvDiff, &vinvDiffT) // U^-1 * (X-M) * V^-1 * (X-M)^T, d×d
+
+	eye := mat64.NewDense(t.d, t.d, nil)
+	for i := 0; i < t.d; i++ {
+		eye.Set(i, i, 1)
+	}
+	inner.Add(eye, &inner)
+	det := mat64.Det(&inner)
+	if det <= 0 {
+		return math.Inf(-1)
+	}
+	logdetinner := math.Log(det)
+
+	fd := float64(t.d)
+	fp := float64(t.p)
+	fnu := t.nu
+
+	return mathext.MvLgamma(0.5*(fnu+fd+fp-1), t.d) - mathext.MvLgamma(0.5*(fnu+fd-1), t.d) -
+		0.5*fp*t.logdetU - 0.5*fd*t.logdetV - 0.5*fd*fp*math.Log(math.Pi) -
+		0.5*(fnu+fd+fp-1)*logdetinner
+}
+
+// Rand generates a random matrix from the distribution. If x is nil, a new
+// matrix is allocated and returned. If x is not nil, the result is stored
+// in-place into x. It must have size d×p or Rand will panic.
+func (t *MatrixTDist) Rand(x *mat64.Dense) *mat64.Dense {
+	// t.cholU and t.cholV are already factorized, so reuse them instead of
+	// re-factorizing U and V from scratch on every call.
+	iw, ok := newInverseWishartFromChol(&t.cholU, t.nu+float64(t.d)-1, t.src)
+	if !ok {
+		panic("distmat: invalid parameters")
+	}
+	var sigma mat64.SymDense
+	iw.RandSym(&sigma)
+
+	mn, ok := newMatrixNormalFromChol(t.mean, &sigma, &t.cholV, t.src)
+	if !ok {
+		panic("distmat: invalid parameters")
+	}
+	return mn.Rand(x)
+}
@@ -0,0 +1,60 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distmat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+// For d=p=1, the d×d determinant |I_d + ...| collapses to a scalar and Γ_1
+// is the ordinary gamma function, so LogProb can be checked directly.
+func TestMatrixTDistLogProb(t *testing.T) {
+	nu, m, u, v, x := 6.0, 1.0, 2.0, 3.0, 2.5
+
+	lg1, _ := math.Lgamma(0.5 * (nu + 1))
+	lg2, _ := math.Lgamma(0.5 * nu)
+	diff := x - m
+	logdetInner := math.Log(1 + diff*diff/(u*v))
+	want := lg1 - lg2 - 0.5*math.Log(u) - 0.5*math.Log(v) - 0.5*math.Log(math.Pi) - 0.5*(nu+1)*logdetInner
+
+	mean := mat64.NewDense(1, 1, []float64{m})
+	src := rand.New(rand.NewSource(1))
+	td, ok := NewMatrixTDist(nu, mean, mat64.NewSymDense(1, []float64{u}), mat64.NewSymDense(1, []float64{v}), src)
+	if !ok {
+		t.Fatal("NewMatrixTDist failed")
+	}
+	got := td.LogProb(mat64.NewDense(1, 1, []float64{x}))
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("logprob mismatch: got %v, want %v", got, want)
+	}
+}
+
+func TestMatrixTDistRand(t *testing.T) {
+	const d, p = 2, 3
+	mean := mat64.NewDense(d, p, nil)
+	u := mat64.NewSymDense(d, nil)
+	for i := 0; i < d; i++ {
+		u.SetSym(i, i, 1)
+	}
+	v := mat64.NewSymDense(p, nil)
+	for i := 0; i < p; i++ {
+		v.SetSym(i, i, 1)
+	}
+	src := rand.New(rand.NewSource(1))
+	td, ok := NewMatrixTDist(10, mean, u, v, src)
+	if !ok {
+		t.Fatal("NewMatrixTDist failed")
+	}
+
+	x := td.Rand(nil)
+	r, c := x.Dims()
+	if r != d || c != p {
+		t.Fatalf("wrong dims: got (%d,%d), want (%d,%d)", r, c, d, p)
+	}
+}
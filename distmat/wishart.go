@@ -145,17 +145,85 @@ func (w *Wishart) logProbSymChol(cholX *mat64.Cholesky) float64 {
 
 // RandSym generates a random symmetric matrix from the distribution.
 func (w *Wishart) RandSym(x *mat64.SymDense) *mat64.SymDense {
+	return w.randSym(w.src, x)
+}
+
+// RandChol generates the Cholesky decomposition of a random matrix from the distribution.
+func (w *Wishart) RandChol(c *mat64.Cholesky) *mat64.Cholesky {
+	return w.randChol(w.src, c)
+}
+
+// RandN generates n independent random symmetric matrices from the
+// distribution, storing the results in dst and returning dst. If dst is nil,
+// a new slice of length n is allocated.
+//
+// The samples are generated in parallel across goroutines, each seeded
+// independently from w's source so that no two goroutines share a source.
+//
+// RandN panics if dst is non-nil and len(dst) != n.
+func (w *Wishart) RandN(n int, dst []mat64.SymDense) []mat64.SymDense {
+	if dst == nil {
+		dst = make([]mat64.SymDense, n)
+	}
+	if len(dst) != n {
+		panic(badDim)
+	}
+	srcs := w.childSources(n)
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			w.randSym(srcs[i], &dst[i])
+		}(i)
+	}
+	wg.Wait()
+	return dst
+}
+
+// RandCholN is like RandN, but generates the Cholesky decomposition of each
+// sample rather than the sample itself.
+func (w *Wishart) RandCholN(n int, dst []mat64.Cholesky) []mat64.Cholesky {
+	if dst == nil {
+		dst = make([]mat64.Cholesky, n)
+	}
+	if len(dst) != n {
+		panic(badDim)
+	}
+	srcs := w.childSources(n)
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			w.randChol(srcs[i], &dst[i])
+		}(i)
+	}
+	wg.Wait()
+	return dst
+}
+
+// childSources returns n independently-seeded *rand.Rand values derived from
+// w's source, for use by goroutines that must not share a single source.
+func (w *Wishart) childSources(n int) []*rand.Rand {
+	srcs := make([]*rand.Rand, n)
+	for i := range srcs {
+		srcs[i] = rand.New(rand.NewSource(w.src.Int63()))
+	}
+	return srcs
+}
+
+func (w *Wishart) randSym(src *rand.Rand, x *mat64.SymDense) *mat64.SymDense {
 	if x == nil {
 		x = &mat64.SymDense{}
 	}
 	var c mat64.Cholesky
-	w.RandChol(&c)
+	w.randChol(src, &c)
 	x.FromCholesky(&c)
 	return x
 }
 
-// RandChol generates the Cholesky decomposition of a random matrix from the distribution.
-func (w *Wishart) RandChol(c *mat64.Cholesky) *mat64.Cholesky {
+func (w *Wishart) randChol(src *rand.Rand, c *mat64.Cholesky) *mat64.Cholesky {
 	// TODO(btracey): Modify the code if the underlying data from c is exposed
 	// to avoid the dim^2 allocation here.
 
@@ -169,34 +237,49 @@ func (w *Wishart) RandChol(c *mat64.Cholesky) *mat64.Cholesky {
 	// mat64 works with the upper triagular decomposition, so we would like to do
 	// the same. We can instead say that
 	//  U_x = L_x^T = (L * A)^T = A^T * L^T = A^T * U
-	// Instead, generate A^T, by using the procedure above, except as an upper
-	// triangular matrix.
+	// BartlettSample generates A^T directly as an upper triangular matrix.
+	t := mat64.NewTriDense(w.dim, matrix.Upper, nil)
+	BartlettSample(w.dim, w.nu, src, t)
+
+	t.MulTri(t, &w.upper)
+	if c == nil {
+		c = &mat64.Cholesky{}
+	}
+	c.SetFromU(t)
+	return c
+}
+
+// BartlettSample generates the Bartlett decomposition factor A^T used to
+// sample from a Wishart distribution of the given dimension and degrees of
+// freedom: an upper triangular matrix whose diagonal entries are the square
+// roots of independent χ² random variables and whose strictly-upper entries
+// are independent standard normal random variables. If dst is nil, a new
+// TriDense is allocated and returned.
+//
+// Combined with the upper Cholesky factor U of the scale matrix, A^T * U
+// gives the upper Cholesky factor of a Wishart sample; see Wishart.RandChol.
+func BartlettSample(dim int, nu float64, src *rand.Rand, dst *mat64.TriDense) *mat64.TriDense {
+	if dst == nil {
+		dst = mat64.NewTriDense(dim, matrix.Upper, nil)
+	}
 	norm := distuv.Normal{
 		Mu:     0,
 		Sigma:  1,
-		Source: w.src,
+		Source: src,
 	}
-
-	t := mat64.NewTriDense(w.dim, matrix.Upper, nil)
-	for i := 0; i < w.dim; i++ {
+	for i := 0; i < dim; i++ {
 		v := distuv.ChiSquared{
-			K:   w.nu - float64(i),
-			Src: w.src,
+			K:   nu - float64(i),
+			Src: src,
 		}.Rand()
-		t.SetTri(i, i, math.Sqrt(v))
+		dst.SetTri(i, i, math.Sqrt(v))
 	}
-	for i := 0; i < w.dim; i++ {
-		for j := i + 1; j < w.dim; j++ {
-			t.SetTri(i, j, norm.Rand())
+	for i := 0; i < dim; i++ {
+		for j := i + 1; j < dim; j++ {
+			dst.SetTri(i, j, norm.Rand())
 		}
 	}
-
-	t.MulTri(t, &w.upper)
-	if c == nil {
-		c = &mat64.Cholesky{}
-	}
-	c.SetFromU(t)
-	return c
+	return dst
 }
 
 // setV computes and stores the covariance matrix of the distribution.
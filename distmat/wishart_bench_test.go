@@ -0,0 +1,53 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distmat
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+func newBenchWishart(dim int) *Wishart {
+	src := rand.New(rand.NewSource(1))
+	v := mat64.NewSymDense(dim, nil)
+	for i := 0; i < dim; i++ {
+		v.SetSym(i, i, 1)
+	}
+	w, ok := NewWishart(v, float64(dim+1), src)
+	if !ok {
+		panic("distmat: bad test setup")
+	}
+	return w
+}
+
+func benchmarkWishartRandSerial(b *testing.B, dim int) {
+	w := newBenchWishart(dim)
+	dst := make([]mat64.SymDense, 100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range dst {
+			w.RandSym(&dst[j])
+		}
+	}
+}
+
+func benchmarkWishartRandN(b *testing.B, dim int) {
+	w := newBenchWishart(dim)
+	dst := make([]mat64.SymDense, 100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w.RandN(len(dst), dst)
+	}
+}
+
+func BenchmarkWishartRandSerial10(b *testing.B)  { benchmarkWishartRandSerial(b, 10) }
+func BenchmarkWishartRandSerial50(b *testing.B)  { benchmarkWishartRandSerial(b, 50) }
+func BenchmarkWishartRandSerial200(b *testing.B) { benchmarkWishartRandSerial(b, 200) }
+
+func BenchmarkWishartRandN10(b *testing.B)  { benchmarkWishartRandN(b, 10) }
+func BenchmarkWishartRandN50(b *testing.B)  { benchmarkWishartRandN(b, 50) }
+func BenchmarkWishartRandN200(b *testing.B) { benchmarkWishartRandN(b, 200) }